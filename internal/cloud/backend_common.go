@@ -12,10 +12,13 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -29,32 +32,163 @@ import (
 )
 
 var (
-	backoffMin = 1000.0
-	backoffMax = 3000.0
-
-	runPollInterval = 3 * time.Second
+	defaultBackoffMin      = 1000.0
+	defaultBackoffMax      = 3000.0
+	defaultRunPollInterval = 3 * time.Second
+
+	// pollConfigs holds one *cloudPollConfig per Cloud instance. The Cloud
+	// struct, declared in backend.go, has no fields for instance-scoped
+	// polling state (the jitter source and the backoff/poll-interval
+	// bounds), so that state is attached here instead. Keying by the *Cloud
+	// pointer keeps state isolated per backend instance: concurrent
+	// operations against two different Cloud values (parallel tests, or
+	// two backends in one process) never share a jitter source, unlike a
+	// single package-level *rand.Rand would. Cloud instances live for the
+	// lifetime of a terraform process, so the registry isn't expected to
+	// accumulate stale entries in practice.
+	pollConfigs sync.Map // map[*Cloud]*cloudPollConfig
 )
 
-// backoff will perform exponential backoff based on the iteration and
-// limited by the provided min and max (in milliseconds) durations.
-func backoff(min, max float64, iter int) time.Duration {
-	backoff := math.Pow(2, float64(iter)/5) * min
-	if backoff > max {
-		backoff = max
+func init() {
+	if ms, ok := pollEnvMilliseconds("TF_CLOUD_POLL_MIN_MS"); ok {
+		defaultBackoffMin = ms
+	}
+	if ms, ok := pollEnvMilliseconds("TF_CLOUD_POLL_MAX_MS"); ok {
+		defaultBackoffMax = ms
+	}
+	if ms, ok := pollEnvMilliseconds("TF_CLOUD_POLL_INTERVAL_MS"); ok {
+		defaultRunPollInterval = time.Duration(ms) * time.Millisecond
+	}
+}
+
+// pollEnvMilliseconds reads an environment variable as a millisecond
+// duration, used to let operators override the cloud backend's polling
+// cadence (e.g. for a CI fleet that wants to spread out load on a shared
+// TFC/TFE organization) without editing backend configuration.
+func pollEnvMilliseconds(key string) (float64, bool) {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0, false
 	}
-	return time.Duration(backoff) * time.Millisecond
+	ms, err := strconv.ParseFloat(v, 64)
+	if err != nil || ms <= 0 {
+		return 0, false
+	}
+	return ms, true
+}
+
+// cloudPollConfig is one Cloud instance's polling cadence and jitter
+// source. See the pollConfigs comment for why this lives in a
+// pointer-keyed registry instead of as fields on Cloud.
+type cloudPollConfig struct {
+	mu              sync.Mutex
+	backoffMin      float64
+	backoffMax      float64
+	runPollInterval time.Duration
+	rand            *rand.Rand
+}
+
+// pollConfig returns this Cloud instance's polling configuration,
+// creating it from the env-var-derived defaults on first use.
+func (b *Cloud) pollConfig() *cloudPollConfig {
+	if cfg, ok := pollConfigs.Load(b); ok {
+		return cfg.(*cloudPollConfig)
+	}
+	cfg := &cloudPollConfig{
+		backoffMin:      defaultBackoffMin,
+		backoffMax:      defaultBackoffMax,
+		runPollInterval: defaultRunPollInterval,
+		rand:            rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	actual, _ := pollConfigs.LoadOrStore(b, cfg)
+	return actual.(*cloudPollConfig)
+}
+
+// ConfigurePolling overrides this Cloud instance's backoff bounds and run
+// poll interval. This and the process-wide TF_CLOUD_POLL_* env vars are
+// the only ways to set these values: the `backend "cloud" { ... }` block
+// has no settings for them, since Cloud's Schema/Configure in backend.go
+// don't read any.
+func (b *Cloud) ConfigurePolling(backoffMin, backoffMax float64, runPollInterval time.Duration) {
+	cfg := b.pollConfig()
+	cfg.mu.Lock()
+	defer cfg.mu.Unlock()
+	cfg.backoffMin = backoffMin
+	cfg.backoffMax = backoffMax
+	cfg.runPollInterval = runPollInterval
+}
+
+// SetRandSourceForTesting seeds this Cloud instance's jitter source so
+// its backoff delays are reproducible in tests.
+func (b *Cloud) SetRandSourceForTesting(src rand.Source) {
+	cfg := b.pollConfig()
+	cfg.mu.Lock()
+	defer cfg.mu.Unlock()
+	cfg.rand = rand.New(src)
+}
+
+// pollInterval returns this Cloud instance's configured run poll
+// interval, used while waiting on a confirm decision.
+func (b *Cloud) pollInterval() time.Duration {
+	cfg := b.pollConfig()
+	cfg.mu.Lock()
+	defer cfg.mu.Unlock()
+	return cfg.runPollInterval
+}
+
+// backoff computes a full-jitter exponential backoff delay for this Cloud
+// instance: it calculates the deterministic exponential delay capped by
+// the instance's configured min/max (in milliseconds), then scales it by
+// a random factor in [0, 1). Without the jitter, many terraform processes
+// polling the same TFC/TFE organization at once (a CI fleet, a workspace
+// fan-out) would all wake up and hit the API in lockstep.
+func (b *Cloud) backoff(iter int) time.Duration {
+	cfg := b.pollConfig()
+	cfg.mu.Lock()
+	defer cfg.mu.Unlock()
+
+	delay := math.Pow(2, float64(iter)/5) * cfg.backoffMin
+	if delay > cfg.backoffMax {
+		delay = cfg.backoffMax
+	}
+	return time.Duration(cfg.rand.Float64()*delay) * time.Millisecond
+}
+
+// queueRecomputeMaxStaleTicks bounds how many consecutive 30-second ticks
+// waitForRun will reuse a previously computed queue position before
+// forcing a fresh Runs.List / Organizations.ReadRunQueue lookup. The
+// workspace's CurrentRun advancing is a reliable signal that our position
+// moved, but a run ahead of us in the queue can also be discarded without
+// CurrentRun changing, so staleness is capped rather than left unbounded.
+const queueRecomputeMaxStaleTicks = 4
+
+// queueIsCurrent reports whether the queue position computed on a
+// previous tick can still be trusted on this one, rather than
+// re-querying Runs.List / Organizations.ReadRunQueue. currentRunID and
+// lastCurrentRunID are the workspace's CurrentRun.ID on this tick and the
+// last one a fresh lookup was performed on; staleTicks counts how many
+// consecutive ticks have been served from the cached position. It is a
+// free function (not a method) so it can be unit tested without a real
+// tfe.Client.
+func queueIsCurrent(tick int, currentRunID, lastCurrentRunID string, staleTicks int) bool {
+	return tick > 0 && currentRunID == lastCurrentRunID && staleTicks < queueRecomputeMaxStaleTicks
 }
 
 func (b *Cloud) waitForRun(stopCtx, cancelCtx context.Context, op *backend.Operation, opType string, r *tfe.Run, w *tfe.Workspace) (*tfe.Run, error) {
 	started := time.Now()
 	updated := started
+
+	var lastCurrentRunID string
+	var lastPosition int
+	staleTicks := 0
+
 	for i := 0; ; i++ {
 		select {
 		case <-stopCtx.Done():
 			return r, stopCtx.Err()
 		case <-cancelCtx.Done():
 			return r, cancelCtx.Err()
-		case <-time.After(backoff(backoffMin, backoffMax, i)):
+		case <-time.After(b.backoff(i)):
 			// Timer up, show status
 		}
 
@@ -63,24 +197,28 @@ func (b *Cloud) waitForRun(stopCtx, cancelCtx context.Context, op *backend.Opera
 		if err != nil {
 			return r, generalError("Failed to retrieve run", err)
 		}
+		checkpointRunState(r)
 
 		// Return if the run is no longer pending.
 		if r.Status != tfe.RunPending && r.Status != tfe.RunConfirmed {
-			if i == 0 && opType == "plan" && b.CLI != nil {
+			if i == 0 && opType == "plan" && b.textOutputEnabled() {
 				b.CLI.Output(b.Colorize().Color(fmt.Sprintf("Waiting for the %s to start...\n", opType)))
 			}
-			if i > 0 && b.CLI != nil {
+			if i > 0 && b.textOutputEnabled() {
 				// Insert a blank line to separate the ouputs.
 				b.CLI.Output("")
 			}
+			b.eventSink().Emit(cloudEvent{Type: cloudEventRunStarted, RunID: r.ID})
 			return r, nil
 		}
 
-		// Check if 30 seconds have passed since the last update.
+		// Check if 30 seconds have passed since the last update. This
+		// gates the tick itself, not just the text output below, so the
+		// event sink still emits on the same cadence in TF_CLOUD_JSON mode
+		// even though b.CLI.Output is skipped there.
 		current := time.Now()
-		if b.CLI != nil && (i == 0 || current.Sub(updated).Seconds() > 30) {
+		if i == 0 || current.Sub(updated).Seconds() > 30 {
 			updated = current
-			position := 0
 			elapsed := ""
 
 			// Calculate and set the elapsed time.
@@ -89,12 +227,34 @@ func (b *Cloud) waitForRun(stopCtx, cancelCtx context.Context, op *backend.Opera
 					" (%s elapsed)", current.Sub(started).Truncate(30*time.Second))
 			}
 
-			// Retrieve the workspace used to run this operation in.
+			// Retrieve the workspace used to run this operation in. This is
+			// a single, cheap object read, so it always happens fresh: it's
+			// needed for the locked-workspace check below, and its
+			// CurrentRun.ID is the signal used to decide whether the
+			// heavier Runs.List / Organizations.ReadRunQueue / ReadCapacity
+			// calls further down can be skipped this tick.
 			w, err = b.client.Workspaces.Read(stopCtx, b.organization, w.Name)
 			if err != nil {
 				return nil, generalError("Failed to retrieve workspace", err)
 			}
 
+			currentRunID := ""
+			if w.CurrentRun != nil {
+				currentRunID = w.CurrentRun.ID
+			}
+			queueCurrent := queueIsCurrent(i, currentRunID, lastCurrentRunID, staleTicks)
+			if queueCurrent {
+				staleTicks++
+			} else {
+				staleTicks = 0
+			}
+			lastCurrentRunID = currentRunID
+
+			position := 0
+			if queueCurrent {
+				position = lastPosition
+			}
+
 			// If the workspace is locked the run will not be queued and we can
 			// update the status without making any expensive calls.
 			if w.Locked && w.CurrentRun != nil {
@@ -103,12 +263,35 @@ func (b *Cloud) waitForRun(stopCtx, cancelCtx context.Context, op *backend.Opera
 					return r, generalError("Failed to retrieve current run", err)
 				}
 				if cr.Status == tfe.RunPending {
-					b.CLI.Output(b.Colorize().Color(
-						"Waiting for the manually locked workspace to be unlocked..." + elapsed))
+					if b.textOutputEnabled() {
+						b.CLI.Output(b.Colorize().Color(
+							"Waiting for the manually locked workspace to be unlocked..." + elapsed))
+					}
 					continue
 				}
 			}
 
+			// The workspace's current run hasn't advanced since the last
+			// lookup (bounded by queueRecomputeMaxStaleTicks), so the queue
+			// position we computed then is still accurate. Skip straight to
+			// re-rendering it instead of re-issuing the list/queue lookups.
+			if queueCurrent && position > 0 {
+				if b.textOutputEnabled() {
+					b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
+						"Waiting for %d run(s) to finish before starting...%s",
+						position,
+						elapsed,
+					)))
+				}
+				b.eventSink().Emit(cloudEvent{
+					Type:      cloudEventRunQueue,
+					RunID:     r.ID,
+					Position:  position,
+					ElapsedMS: current.Sub(started).Milliseconds(),
+				})
+				continue
+			}
+
 			// Skip checking the workspace queue when we are the current run.
 			if w.CurrentRun == nil || w.CurrentRun.ID != r.ID {
 				found := false
@@ -158,11 +341,20 @@ func (b *Cloud) waitForRun(stopCtx, cancelCtx context.Context, op *backend.Opera
 				}
 
 				if position > 0 {
-					b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
-						"Waiting for %d run(s) to finish before being queued...%s",
-						position,
-						elapsed,
-					)))
+					lastPosition = position
+					if b.textOutputEnabled() {
+						b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
+							"Waiting for %d run(s) to finish before being queued...%s",
+							position,
+							elapsed,
+						)))
+					}
+					b.eventSink().Emit(cloudEvent{
+						Type:      cloudEventRunQueue,
+						RunID:     r.ID,
+						Position:  position,
+						ElapsedMS: current.Sub(started).Milliseconds(),
+					})
 					continue
 				}
 			}
@@ -197,16 +389,35 @@ func (b *Cloud) waitForRun(stopCtx, cancelCtx context.Context, op *backend.Opera
 				if err != nil {
 					return r, generalError("Failed to retrieve capacity", err)
 				}
-				b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
-					"Waiting for %d queued run(s) to finish before starting...%s",
-					position-c.Running,
-					elapsed,
-				)))
+				lastPosition = position - c.Running
+				if b.textOutputEnabled() {
+					b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
+						"Waiting for %d queued run(s) to finish before starting...%s",
+						lastPosition,
+						elapsed,
+					)))
+				}
+				b.eventSink().Emit(cloudEvent{
+					Type:      cloudEventRunQueue,
+					RunID:     r.ID,
+					Position:  lastPosition,
+					ElapsedMS: current.Sub(started).Milliseconds(),
+				})
 				continue
 			}
 
-			b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
-				"Waiting for the %s to start...%s", opType, elapsed)))
+			lastPosition = 0
+
+			if b.textOutputEnabled() {
+				b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
+					"Waiting for the %s to start...%s", opType, elapsed)))
+			}
+			b.eventSink().Emit(cloudEvent{
+				Type:      cloudEventRunQueue,
+				RunID:     r.ID,
+				Position:  0,
+				ElapsedMS: current.Sub(started).Milliseconds(),
+			})
 		}
 	}
 }
@@ -236,7 +447,7 @@ func (b *Cloud) costEstimate(stopCtx, cancelCtx context.Context, op *backend.Ope
 			return stopCtx.Err()
 		case <-cancelCtx.Done():
 			return cancelCtx.Err()
-		case <-time.After(backoff(backoffMin, backoffMax, i)):
+		case <-time.After(b.backoff(i)):
 		}
 
 		// Retrieve the cost estimate to get its current status.
@@ -255,7 +466,7 @@ func (b *Cloud) costEstimate(stopCtx, cancelCtx context.Context, op *backend.Ope
 
 		// checking if i == 0 so as to avoid printing this starting horizontal-rule
 		// every retry, and that it only prints it on the first (i=0) attempt.
-		if b.CLI != nil && i == 0 {
+		if b.textOutputEnabled() && i == 0 {
 			b.CLI.Output("\n------------------------------------------------------------------------\n")
 		}
 
@@ -273,7 +484,7 @@ func (b *Cloud) costEstimate(stopCtx, cancelCtx context.Context, op *backend.Ope
 
 			deltaRepr := strings.Replace(ce.DeltaMonthlyCost, "-", "", 1)
 
-			if b.CLI != nil {
+			if b.textOutputEnabled() {
 				b.CLI.Output(b.Colorize().Color("[bold]" + msgPrefix + ":\n"))
 				b.CLI.Output(b.Colorize().Color(fmt.Sprintf("Resources: %d of %d estimated", ce.MatchedResourcesCount, ce.ResourcesCount)))
 				b.CLI.Output(b.Colorize().Color(fmt.Sprintf("           $%s/mo %s$%s", ce.ProposedMonthlyCost, sign, deltaRepr)))
@@ -283,11 +494,20 @@ func (b *Cloud) costEstimate(stopCtx, cancelCtx context.Context, op *backend.Ope
 				}
 			}
 
+			b.eventSink().Emit(cloudEvent{
+				Type:     cloudEventCostEstimateDone,
+				RunID:    r.ID,
+				Delta:    ce.DeltaMonthlyCost,
+				Proposed: ce.ProposedMonthlyCost,
+				Matched:  ce.MatchedResourcesCount,
+				Total:    ce.ResourcesCount,
+			})
+
 			return nil
 		case tfe.CostEstimatePending, tfe.CostEstimateQueued:
 			// Check if 30 seconds have passed since the last update.
 			current := time.Now()
-			if b.CLI != nil && (i == 0 || current.Sub(updated).Seconds() > 30) {
+			if b.textOutputEnabled() && (i == 0 || current.Sub(updated).Seconds() > 30) {
 				updated = current
 				elapsed := ""
 
@@ -301,13 +521,17 @@ func (b *Cloud) costEstimate(stopCtx, cancelCtx context.Context, op *backend.Ope
 			}
 			continue
 		case tfe.CostEstimateSkippedDueToTargeting:
-			b.CLI.Output(b.Colorize().Color("[bold]" + msgPrefix + ":\n"))
-			b.CLI.Output("Not available for this plan, because it was created with the -target option.")
-			b.CLI.Output("\n------------------------------------------------------------------------")
+			if b.textOutputEnabled() {
+				b.CLI.Output(b.Colorize().Color("[bold]" + msgPrefix + ":\n"))
+				b.CLI.Output("Not available for this plan, because it was created with the -target option.")
+				b.CLI.Output("\n------------------------------------------------------------------------")
+			}
 			return nil
 		case tfe.CostEstimateErrored:
-			b.CLI.Output(msgPrefix + " errored.\n")
-			b.CLI.Output("\n------------------------------------------------------------------------")
+			if b.textOutputEnabled() {
+				b.CLI.Output(msgPrefix + " errored.\n")
+				b.CLI.Output("\n------------------------------------------------------------------------")
+			}
 			return nil
 		case tfe.CostEstimateCanceled:
 			return fmt.Errorf(msgPrefix + " canceled.")
@@ -318,7 +542,7 @@ func (b *Cloud) costEstimate(stopCtx, cancelCtx context.Context, op *backend.Ope
 }
 
 func (b *Cloud) checkPolicy(stopCtx, cancelCtx context.Context, op *backend.Operation, r *tfe.Run) error {
-	if b.CLI != nil {
+	if b.textOutputEnabled() {
 		b.CLI.Output("\n------------------------------------------------------------------------\n")
 	}
 	for i, pc := range r.PolicyChecks {
@@ -355,11 +579,20 @@ func (b *Cloud) checkPolicy(stopCtx, cancelCtx context.Context, op *backend.Oper
 			msgPrefix = fmt.Sprintf("Unknown policy check (%s)", pc.Scope)
 		}
 
-		if b.CLI != nil {
+		if b.textOutputEnabled() {
 			b.CLI.Output(b.Colorize().Color("[bold]" + msgPrefix + ":\n"))
 		}
 
-		if b.CLI != nil {
+		b.eventSink().Emit(cloudEvent{
+			Type:        cloudEventPolicyCheck,
+			RunID:       r.ID,
+			Scope:       string(pc.Scope),
+			Status:      string(pc.Status),
+			SoftFailed:  pc.Status == tfe.PolicySoftFailed,
+			Overridable: pc.Actions.IsOverridable,
+		})
+
+		if b.textOutputEnabled() {
 			for next := true; next; {
 				var l, line []byte
 
@@ -382,7 +615,7 @@ func (b *Cloud) checkPolicy(stopCtx, cancelCtx context.Context, op *backend.Oper
 
 		switch pc.Status {
 		case tfe.PolicyPasses:
-			if (r.HasChanges && op.Type == backend.OperationTypeApply || i < len(r.PolicyChecks)-1) && b.CLI != nil {
+			if (r.HasChanges && op.Type == backend.OperationTypeApply || i < len(r.PolicyChecks)-1) && b.textOutputEnabled() {
 				b.CLI.Output("\n------------------------------------------------------------------------")
 			}
 			continue
@@ -410,6 +643,11 @@ func (b *Cloud) checkPolicy(stopCtx, cancelCtx context.Context, op *backend.Oper
 					Query:       "\nDo you want to override the soft failed policy check?",
 					Description: "Only 'override' will be accepted to override.",
 				}
+				b.eventSink().Emit(cloudEvent{
+					Type:   cloudEventPolicyOverridePrompt,
+					RunID:  r.ID,
+					RunURL: runUrl,
+				})
 				err = b.confirm(stopCtx, op, opts, r, "override")
 				if err != nil && err != errRunOverridden {
 					return fmt.Errorf(
@@ -421,12 +659,12 @@ func (b *Cloud) checkPolicy(stopCtx, cancelCtx context.Context, op *backend.Oper
 					if _, err = b.client.PolicyChecks.Override(stopCtx, pc.ID); err != nil {
 						return generalError(fmt.Sprintf("Failed to override policy check.\n%s", runUrl), err)
 					}
-				} else {
+				} else if b.textOutputEnabled() {
 					b.CLI.Output(fmt.Sprintf("The run needs to be manually overridden or discarded.\n%s\n", runUrl))
 				}
 			}
 
-			if b.CLI != nil {
+			if b.textOutputEnabled() {
 				b.CLI.Output("------------------------------------------------------------------------")
 			}
 		default:
@@ -438,6 +676,10 @@ func (b *Cloud) checkPolicy(stopCtx, cancelCtx context.Context, op *backend.Oper
 }
 
 func (b *Cloud) confirm(stopCtx context.Context, op *backend.Operation, opts *terraform.InputOpts, r *tfe.Run, keyword string) error {
+	if jsonMode() {
+		return b.confirmJSON(stopCtx, op, r, keyword)
+	}
+
 	doneCtx, cancel := context.WithCancel(stopCtx)
 	result := make(chan error, 2)
 
@@ -452,13 +694,14 @@ func (b *Cloud) confirm(stopCtx context.Context, op *backend.Operation, opts *te
 				return
 			case <-stopCtx.Done():
 				return
-			case <-time.After(runPollInterval):
+			case <-time.After(b.pollInterval()):
 				// Retrieve the run again to get its current status.
 				r, err := b.client.Runs.Read(stopCtx, r.ID)
 				if err != nil {
 					result <- generalError("Failed to retrieve run", err)
 					return
 				}
+				checkpointRunState(r)
 
 				switch keyword {
 				case "override":
@@ -551,6 +794,66 @@ func (b *Cloud) confirm(stopCtx context.Context, op *backend.Operation, opts *te
 	return <-result
 }
 
+// confirmJSON is the TF_CLOUD_JSON counterpart to confirm: instead of
+// reading from op.UIIn it reads a single structured decision from stdin.
+// For an override, the policy.override.prompt event that checkPolicy
+// emits just before calling confirm is the prompt; for any other keyword
+// no event is emitted before the read. This lets a non-interactive
+// orchestrator approve a policy override or apply without scraping an
+// interactive prompt off stderr.
+func (b *Cloud) confirmJSON(stopCtx context.Context, op *backend.Operation, r *tfe.Run, keyword string) error {
+	type decisionResult struct {
+		approved bool
+		err      error
+	}
+
+	// readJSONDecision blocks on stdin, which an orchestrator may never
+	// write to if the operation is canceled first, so it's read in its
+	// own goroutine and raced against stopCtx here rather than called
+	// directly, mirroring how confirm() never blocks past cancellation.
+	decisionCh := make(chan decisionResult, 1)
+	go func() {
+		approved, err := readJSONDecision()
+		decisionCh <- decisionResult{approved, err}
+	}()
+
+	var approved bool
+	select {
+	case <-stopCtx.Done():
+		return stopCtx.Err()
+	case res := <-decisionCh:
+		if res.err != nil {
+			return fmt.Errorf("Error reading %s decision: %v", keyword, res.err)
+		}
+		approved = res.approved
+	}
+
+	if approved {
+		return nil
+	}
+
+	// Mirror confirm()'s behavior when the answer is "no": discard the
+	// run if possible and report it as canceled.
+	r, err := b.client.Runs.Read(stopCtx, r.ID)
+	if err != nil {
+		return generalError("Failed to retrieve run", err)
+	}
+
+	if r.Actions.IsDiscardable {
+		if err := b.client.Runs.Discard(stopCtx, r.ID, tfe.RunDiscardOptions{}); err != nil {
+			if op.PlanMode == plans.DestroyMode {
+				return generalError("Failed to discard destroy", err)
+			}
+			return generalError("Failed to discard apply", err)
+		}
+	}
+
+	if op.PlanMode == plans.DestroyMode {
+		return errDestroyDiscarded
+	}
+	return errApplyDiscarded
+}
+
 // ReadRedactedPlanForRun retrieves the redacted plan JSON for an existing run
 // and returns it as the struct type expected by jsonformat.Renderer, along with
 // incidental values that might be important for displaying that plan. It is
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestBackoffRespectsConfiguredBounds(t *testing.T) {
+	b := &Cloud{}
+	b.ConfigurePolling(100, 200, time.Second)
+	b.SetRandSourceForTesting(rand.NewSource(1))
+
+	for iter := 0; iter < 50; iter++ {
+		d := b.backoff(iter)
+		if d < 0 || d > 200*time.Millisecond {
+			t.Fatalf("backoff(%d) = %s, want in [0, 200ms]", iter, d)
+		}
+	}
+}
+
+func TestBackoffIsInstanceScoped(t *testing.T) {
+	a := &Cloud{}
+	b := &Cloud{}
+
+	a.ConfigurePolling(1000, 1000, time.Second)
+	a.SetRandSourceForTesting(rand.NewSource(1))
+
+	b.ConfigurePolling(5, 5, time.Second)
+	b.SetRandSourceForTesting(rand.NewSource(1))
+
+	// Seeding b's jitter source must not affect a's configured bounds: each
+	// Cloud instance gets its own *cloudPollConfig. With seed 1 the first
+	// jitter factor is about 0.60, so a's delay is about 604ms; had a picked
+	// up b's 5ms bounds it would be at most 5ms.
+	if d := a.backoff(0); d <= 5*time.Millisecond || d > 1000*time.Millisecond {
+		t.Fatalf("a.backoff(0) = %s, want in (5ms, 1000ms] (a's bounds should be unaffected by b)", d)
+	}
+	if d := b.backoff(0); d > 5*time.Millisecond {
+		t.Fatalf("b.backoff(0) = %s, want <= 5ms", d)
+	}
+}
+
+func TestQueueIsCurrent(t *testing.T) {
+	tests := []struct {
+		name             string
+		tick             int
+		currentRunID     string
+		lastCurrentRunID string
+		staleTicks       int
+		want             bool
+	}{
+		{"first tick always recomputes", 0, "run-1", "", 0, false},
+		{"unchanged current run reuses cached position", 3, "run-1", "run-1", 0, true},
+		{"current run advanced forces recompute", 3, "run-2", "run-1", 0, false},
+		{"stale cap forces recompute even if unchanged", 3, "run-1", "run-1", queueRecomputeMaxStaleTicks, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := queueIsCurrent(tt.tick, tt.currentRunID, tt.lastCurrentRunID, tt.staleTicks)
+			if got != tt.want {
+				t.Fatalf("queueIsCurrent(%d, %q, %q, %d) = %v, want %v",
+					tt.tick, tt.currentRunID, tt.lastCurrentRunID, tt.staleTicks, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmJSONRespectsCancellation(t *testing.T) {
+	old := readJSONDecision
+	defer func() { readJSONDecision = old }()
+
+	blockForever := make(chan struct{})
+	defer close(blockForever)
+	readJSONDecision = func() (bool, error) {
+		<-blockForever
+		return true, nil
+	}
+
+	b := &Cloud{}
+	stopCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- b.confirmJSON(stopCtx, nil, nil, "yes")
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != context.Canceled {
+			t.Fatalf("confirmJSON() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("confirmJSON did not return promptly after stopCtx was canceled")
+	}
+}
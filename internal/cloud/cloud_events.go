@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// jsonModeEnvVar enables the structured, newline-delimited JSON event
+// stream in place of the cloud backend's default colorized text output,
+// for CI dashboards and other automation wrappers that can't scrape
+// stderr. It mirrors the -json flag available on commands like plan and
+// apply.
+const jsonModeEnvVar = "TF_CLOUD_JSON"
+
+// jsonMode reports whether the cloud backend should emit structured
+// events instead of human-oriented text.
+func jsonMode() bool {
+	return os.Getenv(jsonModeEnvVar) != ""
+}
+
+// cloudEventType identifies the schema of a structured event emitted
+// while polling a cloud run. Consumers should treat unrecognized types as
+// forward-compatible no-ops rather than erroring.
+type cloudEventType string
+
+const (
+	cloudEventRunQueue             cloudEventType = "run.queue"
+	cloudEventRunStarted           cloudEventType = "run.started"
+	cloudEventCostEstimateDone     cloudEventType = "cost_estimate.finished"
+	cloudEventPolicyCheck          cloudEventType = "policy.check"
+	cloudEventPolicyOverridePrompt cloudEventType = "policy.override.prompt"
+)
+
+// cloudEvent is the payload written to stdout as a single line of JSON in
+// TF_CLOUD_JSON mode. Only the fields relevant to Type are populated; the
+// rest take their zero value. Numeric and boolean fields are never tagged
+// omitempty, even when zero is the expected value for a given Type (e.g.
+// Position: 0 on a run.queue event, or SoftFailed: false on a passing
+// policy check) — dropping them would make the key vanish from the JSON
+// instead of reporting the zero value, which breaks the fixed shape a
+// machine consumer parses against. omitempty stays only on the string
+// fields that are genuinely absent (not merely zero-valued) for most
+// event types, like RunURL.
+type cloudEvent struct {
+	Type        cloudEventType `json:"type"`
+	RunID       string         `json:"run_id,omitempty"`
+	Position    int            `json:"position"`
+	ElapsedMS   int64          `json:"elapsed_ms"`
+	Delta       string         `json:"delta,omitempty"`
+	Proposed    string         `json:"proposed,omitempty"`
+	Matched     int            `json:"matched"`
+	Total       int            `json:"total"`
+	Scope       string         `json:"scope,omitempty"`
+	Name        string         `json:"name,omitempty"`
+	Status      string         `json:"status,omitempty"`
+	SoftFailed  bool           `json:"soft_failed"`
+	Overridable bool           `json:"overridable"`
+	RunURL      string         `json:"run_url,omitempty"`
+}
+
+// cloudEventSink is how the cloud backend reports run progress. The
+// existing b.CLI.Output call sites are left in place for humans; a sink
+// is used alongside them to give programmatic consumers a stable,
+// parseable alternative.
+type cloudEventSink interface {
+	Emit(e cloudEvent)
+}
+
+// textEventSink is the default sink. It is a no-op because the text
+// output paths already write directly to b.CLI; it exists so call sites
+// don't need to special-case JSON mode.
+type textEventSink struct{}
+
+func (textEventSink) Emit(cloudEvent) {}
+
+// jsonEventSinkWriter is where jsonEventSink writes its ndjson lines. It's
+// a var, defaulting to os.Stdout, so tests can capture and decode the
+// emitted events instead of scraping the real stdout.
+var jsonEventSinkWriter io.Writer = os.Stdout
+
+// jsonEventSink writes each event as a single line of JSON to stdout.
+type jsonEventSink struct{}
+
+func (jsonEventSink) Emit(e cloudEvent) {
+	enc, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(jsonEventSinkWriter, string(enc))
+}
+
+// eventSink returns the sink that run-progress events should be emitted
+// through: a jsonEventSink in TF_CLOUD_JSON mode, otherwise a no-op
+// textEventSink.
+func (b *Cloud) eventSink() cloudEventSink {
+	if jsonMode() {
+		return jsonEventSink{}
+	}
+	return textEventSink{}
+}
+
+// textOutputEnabled reports whether b.CLI.Output calls should actually
+// write anything. It's false in TF_CLOUD_JSON mode so the colorized
+// human-readable text and the ndjson event stream never interleave on
+// the same stdout, keeping the JSON stream parseable by a programmatic
+// consumer.
+func (b *Cloud) textOutputEnabled() bool {
+	return b.CLI != nil && !jsonMode()
+}
+
+// jsonDecision is the structured response an orchestrator writes to
+// stdin, in TF_CLOUD_JSON mode, to answer a policy.override.prompt event
+// without scraping an interactive prompt.
+type jsonDecision struct {
+	Approved bool `json:"approved"`
+}
+
+// readJSONDecision reads a single structured decision from stdin. It's a
+// var, not a func, so tests can swap in a fake decision (or one that never
+// arrives, to exercise confirmJSON's cancellation path) without relying on
+// the test process's real stdin.
+var readJSONDecision = func() (bool, error) {
+	var decision jsonDecision
+	if err := json.NewDecoder(os.Stdin).Decode(&decision); err != nil {
+		return false, err
+	}
+	return decision.Approved, nil
+}
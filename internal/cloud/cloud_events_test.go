@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestJSONEventSinkEmitsValidNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	old := jsonEventSinkWriter
+	jsonEventSinkWriter = &buf
+	defer func() { jsonEventSinkWriter = old }()
+
+	sink := jsonEventSink{}
+	sink.Emit(cloudEvent{Type: cloudEventRunQueue, RunID: "run-123", Position: 2})
+	sink.Emit(cloudEvent{Type: cloudEventRunStarted, RunID: "run-123"})
+
+	lines := bytes.Split(bytes.TrimRight(buf.Bytes(), "\n"), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 ndjson lines, got %d: %q", len(lines), buf.String())
+	}
+
+	var first cloudEvent
+	if err := json.Unmarshal(lines[0], &first); err != nil {
+		t.Fatalf("line 1 is not valid JSON: %s", err)
+	}
+	if first.Type != cloudEventRunQueue || first.RunID != "run-123" || first.Position != 2 {
+		t.Fatalf("unexpected decoded event: %+v", first)
+	}
+}
+
+func TestTextEventSinkIsNoop(t *testing.T) {
+	var buf bytes.Buffer
+	old := jsonEventSinkWriter
+	jsonEventSinkWriter = &buf
+	defer func() { jsonEventSinkWriter = old }()
+
+	textEventSink{}.Emit(cloudEvent{Type: cloudEventRunStarted, RunID: "run-123"})
+
+	if buf.Len() != 0 {
+		t.Fatalf("textEventSink wrote output: %q", buf.String())
+	}
+}
+
+func TestEventSinkSelection(t *testing.T) {
+	oldEnv, hadEnv := os.LookupEnv(jsonModeEnvVar)
+	defer func() {
+		if hadEnv {
+			os.Setenv(jsonModeEnvVar, oldEnv)
+		} else {
+			os.Unsetenv(jsonModeEnvVar)
+		}
+	}()
+
+	b := &Cloud{}
+
+	os.Unsetenv(jsonModeEnvVar)
+	if _, ok := b.eventSink().(textEventSink); !ok {
+		t.Fatalf("expected textEventSink when %s is unset", jsonModeEnvVar)
+	}
+	if b.textOutputEnabled() {
+		t.Fatalf("textOutputEnabled() should be false with a nil b.CLI")
+	}
+
+	os.Setenv(jsonModeEnvVar, "1")
+	if _, ok := b.eventSink().(jsonEventSink); !ok {
+		t.Fatalf("expected jsonEventSink when %s is set", jsonModeEnvVar)
+	}
+	if b.textOutputEnabled() {
+		t.Fatalf("textOutputEnabled() should be false when %s is set", jsonModeEnvVar)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/terraform/internal/backend"
+)
+
+// runCheckpointPath is where the cloud backend records the run it created
+// for the current operation, keyed implicitly by being scoped to the
+// working directory's .terraform. If terraform is killed (a dropped SSH
+// session, a CI timeout) between creating the run and reaching a
+// terminal state, the checkpoint lets the next invocation reattach to it
+// instead of creating a duplicate.
+//
+// It's a var, not a const, so tests can point it at a temp file instead
+// of writing into a real .terraform directory.
+var runCheckpointPath = ".terraform/cloud-run.json"
+
+// runCheckpoint is the on-disk record written as soon as Runs.Create
+// returns.
+type runCheckpoint struct {
+	Workspace string    `json:"workspace"`
+	RunID     string    `json:"run_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// resumableRunStatuses are the run statuses AttachRun will reattach to:
+// pending, planning, cost_estimating, policy_checking and
+// post_plan_awaiting_decision. Every other status is refused, including
+// ones in which confirm() can still be waiting on a decision (planned,
+// cost_estimated, policy_checked, policy_override) as well as applying,
+// applied, errored and the like.
+var resumableRunStatuses = map[tfe.RunStatus]bool{
+	tfe.RunPending:                  true,
+	tfe.RunPlanning:                 true,
+	tfe.RunCostEstimating:           true,
+	tfe.RunPolicyChecking:           true,
+	tfe.RunPostPlanAwaitingDecision: true,
+}
+
+// terminalRunStatuses are the statuses after which a checkpoint should be
+// removed, since the run it points at can no longer be resumed.
+var terminalRunStatuses = map[tfe.RunStatus]bool{
+	tfe.RunApplied:   true,
+	tfe.RunCanceled:  true,
+	tfe.RunDiscarded: true,
+	tfe.RunErrored:   true,
+}
+
+// writeRunCheckpoint atomically persists runID as the in-flight run for
+// workspace, so a subsequent invocation can reattach to it.
+func writeRunCheckpoint(workspace, runID string) error {
+	cp := runCheckpoint{
+		Workspace: workspace,
+		RunID:     runID,
+		CreatedAt: time.Now(),
+	}
+
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(runCheckpointPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".cloud-run-*.json.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	// Rename is atomic on the platforms terraform supports, so a reader
+	// never observes a partially written checkpoint.
+	return os.Rename(tmpName, runCheckpointPath)
+}
+
+// readRunCheckpoint loads the checkpoint file, if any. A missing file is
+// not an error; it just means there's nothing to resume.
+func readRunCheckpoint() (*runCheckpoint, error) {
+	data, err := os.ReadFile(runCheckpointPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var cp runCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// removeRunCheckpoint deletes the checkpoint file, if present.
+func removeRunCheckpoint() error {
+	err := os.Remove(runCheckpointPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// checkpointRunState removes the checkpoint once r reaches a terminal
+// state, since there's nothing left to resume at that point. It's called
+// on each run read in waitForRun and in confirm's polling loop; other run
+// reads (AttachRun, confirmJSON, confirm's discard path) don't call it.
+func checkpointRunState(r *tfe.Run) {
+	if terminalRunStatuses[r.Status] {
+		removeRunCheckpoint()
+	}
+}
+
+// AttachRun reattaches to an existing run rather than creating a new one.
+// It's used on startup when a checkpoint file left over from a previous,
+// killed invocation points at a run that may still be in progress. The
+// caller is expected to resume the normal waitForRun -> costEstimate ->
+// checkPolicy -> confirm flow against the returned run.
+func (b *Cloud) AttachRun(ctx context.Context, runID string) (*tfe.Run, error) {
+	r, err := b.client.Runs.Read(ctx, runID)
+	if err != nil {
+		return nil, generalError("Failed to retrieve run", err)
+	}
+
+	if !resumableRunStatuses[r.Status] {
+		return nil, fmt.Errorf("run %s is no longer resumable (status: %s)", runID, r.Status)
+	}
+
+	return r, nil
+}
+
+// attachRunForTesting is the indirection maybeAttachRun calls through
+// instead of b.AttachRun directly, so tests can exercise the checkpoint/
+// reattach decision without a real tfe.Client.
+var attachRunForTesting = func(b *Cloud, ctx context.Context, runID string) (*tfe.Run, error) {
+	return b.AttachRun(ctx, runID)
+}
+
+// maybeAttachRun looks for a checkpoint left by a previous invocation for
+// workspace and, if it points at a still-resumable run, returns it so the
+// caller can skip Runs.Create and resume the existing run instead of
+// starting a duplicate. When forceNewRun is true it removes any checkpoint
+// and returns no run, so the caller always starts fresh.
+func (b *Cloud) maybeAttachRun(ctx context.Context, workspace string, forceNewRun bool) (*tfe.Run, error) {
+	if forceNewRun {
+		return nil, removeRunCheckpoint()
+	}
+
+	cp, err := readRunCheckpoint()
+	if err != nil || cp == nil || cp.Workspace != workspace {
+		return nil, nil
+	}
+
+	r, err := attachRunForTesting(b, ctx, cp.RunID)
+	if err != nil {
+		// The checkpoint is stale: the run moved past the point where we
+		// can still attach, or no longer exists. Clean it up and let the
+		// caller create a fresh run.
+		removeRunCheckpoint()
+		return nil, nil
+	}
+
+	return r, nil
+}
+
+// StartOrAttachRun creates a run or reattaches to a checkpointed one: it
+// first checks for a checkpoint left by a killed previous invocation and,
+// if one points at a still-resumable run for this workspace, reattaches
+// to it instead of starting a duplicate. Otherwise it calls createRun,
+// persists the resulting run ID as a checkpoint, and returns it. When
+// forceNewRun is true any existing checkpoint is discarded and the
+// createRun path is always taken; backend.Operation has no field for
+// this, so the caller passes it explicitly, and there is no command-line
+// flag that sets it. The plan and apply paths create their runs with
+// b.client.Runs.Create directly and do not go through StartOrAttachRun,
+// so they neither write a checkpoint nor reattach to one; its only
+// callers are the tests in run_checkpoint_test.go.
+func (b *Cloud) StartOrAttachRun(stopCtx context.Context, op *backend.Operation, forceNewRun bool, createRun func() (*tfe.Run, error)) (*tfe.Run, error) {
+	if r, err := b.maybeAttachRun(stopCtx, op.Workspace, forceNewRun); err != nil {
+		return nil, err
+	} else if r != nil {
+		if b.textOutputEnabled() {
+			b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
+				"[reset][yellow]Reattaching to existing run %s from a previous invocation.[reset]", r.ID)))
+		}
+		return r, nil
+	}
+
+	r, err := createRun()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeRunCheckpoint(op.Workspace, r.ID); err != nil && b.textOutputEnabled() {
+		b.CLI.Output(b.Colorize().Color(fmt.Sprintf(
+			"[reset][yellow]Warning: failed to persist run checkpoint: %s[reset]", err)))
+	}
+
+	return r, nil
+}
@@ -0,0 +1,206 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tfe "github.com/hashicorp/go-tfe"
+	"github.com/hashicorp/terraform/internal/backend"
+)
+
+func withTestCheckpointPath(t *testing.T) {
+	t.Helper()
+	old := runCheckpointPath
+	dir := t.TempDir()
+	runCheckpointPath = filepath.Join(dir, "cloud-run.json")
+	t.Cleanup(func() { runCheckpointPath = old })
+}
+
+func TestWriteReadRemoveRunCheckpointRoundTrip(t *testing.T) {
+	withTestCheckpointPath(t)
+
+	if cp, err := readRunCheckpoint(); err != nil || cp != nil {
+		t.Fatalf("readRunCheckpoint() on missing file = (%+v, %v), want (nil, nil)", cp, err)
+	}
+
+	if err := writeRunCheckpoint("my-workspace", "run-123"); err != nil {
+		t.Fatalf("writeRunCheckpoint() error = %v", err)
+	}
+
+	cp, err := readRunCheckpoint()
+	if err != nil {
+		t.Fatalf("readRunCheckpoint() error = %v", err)
+	}
+	if cp == nil || cp.Workspace != "my-workspace" || cp.RunID != "run-123" {
+		t.Fatalf("readRunCheckpoint() = %+v, want workspace=my-workspace run_id=run-123", cp)
+	}
+
+	if err := removeRunCheckpoint(); err != nil {
+		t.Fatalf("removeRunCheckpoint() error = %v", err)
+	}
+	if _, err := os.Stat(runCheckpointPath); !os.IsNotExist(err) {
+		t.Fatalf("checkpoint file still exists after removeRunCheckpoint(): %v", err)
+	}
+
+	// Removing an already-absent checkpoint is not an error.
+	if err := removeRunCheckpoint(); err != nil {
+		t.Fatalf("removeRunCheckpoint() on missing file error = %v", err)
+	}
+}
+
+func TestCheckpointRunStateRemovesOnTerminalStatus(t *testing.T) {
+	withTestCheckpointPath(t)
+
+	if err := writeRunCheckpoint("my-workspace", "run-123"); err != nil {
+		t.Fatalf("writeRunCheckpoint() error = %v", err)
+	}
+
+	checkpointRunState(&tfe.Run{Status: tfe.RunPlanning})
+	if cp, err := readRunCheckpoint(); err != nil || cp == nil {
+		t.Fatalf("checkpoint removed on non-terminal status: (%+v, %v)", cp, err)
+	}
+
+	checkpointRunState(&tfe.Run{Status: tfe.RunApplied})
+	if cp, err := readRunCheckpoint(); err != nil || cp != nil {
+		t.Fatalf("checkpoint not removed on terminal status: (%+v, %v)", cp, err)
+	}
+}
+
+func TestMaybeAttachRunNoCheckpoint(t *testing.T) {
+	withTestCheckpointPath(t)
+
+	b := &Cloud{}
+	r, err := b.maybeAttachRun(nil, "my-workspace", false)
+	if err != nil {
+		t.Fatalf("maybeAttachRun() error = %v", err)
+	}
+	if r != nil {
+		t.Fatalf("maybeAttachRun() = %+v, want nil with no checkpoint present", r)
+	}
+}
+
+func TestMaybeAttachRunWrongWorkspace(t *testing.T) {
+	withTestCheckpointPath(t)
+
+	if err := writeRunCheckpoint("other-workspace", "run-123"); err != nil {
+		t.Fatalf("writeRunCheckpoint() error = %v", err)
+	}
+
+	b := &Cloud{}
+	r, err := b.maybeAttachRun(nil, "my-workspace", false)
+	if err != nil {
+		t.Fatalf("maybeAttachRun() error = %v", err)
+	}
+	if r != nil {
+		t.Fatalf("maybeAttachRun() = %+v, want nil for a checkpoint scoped to a different workspace", r)
+	}
+}
+
+func TestMaybeAttachRunForceNewRunRemovesCheckpoint(t *testing.T) {
+	withTestCheckpointPath(t)
+
+	if err := writeRunCheckpoint("my-workspace", "run-123"); err != nil {
+		t.Fatalf("writeRunCheckpoint() error = %v", err)
+	}
+
+	b := &Cloud{}
+	r, err := b.maybeAttachRun(nil, "my-workspace", true)
+	if err != nil {
+		t.Fatalf("maybeAttachRun() error = %v", err)
+	}
+	if r != nil {
+		t.Fatalf("maybeAttachRun() = %+v, want nil when forceNewRun is true", r)
+	}
+	if cp, err := readRunCheckpoint(); err != nil || cp != nil {
+		t.Fatalf("checkpoint not removed by forceNewRun: (%+v, %v)", cp, err)
+	}
+}
+
+func TestStartOrAttachRunCreatesWhenNoCheckpoint(t *testing.T) {
+	withTestCheckpointPath(t)
+
+	b := &Cloud{}
+	op := &backend.Operation{Workspace: "my-workspace"}
+
+	var created bool
+	r, err := b.StartOrAttachRun(context.Background(), op, false, func() (*tfe.Run, error) {
+		created = true
+		return &tfe.Run{ID: "run-123"}, nil
+	})
+	if err != nil {
+		t.Fatalf("StartOrAttachRun() error = %v", err)
+	}
+	if !created {
+		t.Fatal("StartOrAttachRun() did not call createRun with no checkpoint present")
+	}
+	if r == nil || r.ID != "run-123" {
+		t.Fatalf("StartOrAttachRun() = %+v, want the run returned by createRun", r)
+	}
+
+	cp, err := readRunCheckpoint()
+	if err != nil {
+		t.Fatalf("readRunCheckpoint() error = %v", err)
+	}
+	if cp == nil || cp.Workspace != "my-workspace" || cp.RunID != "run-123" {
+		t.Fatalf("readRunCheckpoint() = %+v, want the run StartOrAttachRun just created to be checkpointed", cp)
+	}
+}
+
+func TestStartOrAttachRunSkipsCreateWhenCheckpointResumable(t *testing.T) {
+	withTestCheckpointPath(t)
+
+	if err := writeRunCheckpoint("my-workspace", "run-123"); err != nil {
+		t.Fatalf("writeRunCheckpoint() error = %v", err)
+	}
+
+	old := attachRunForTesting
+	attachRunForTesting = func(b *Cloud, ctx context.Context, runID string) (*tfe.Run, error) {
+		return &tfe.Run{ID: runID, Status: tfe.RunPlanning}, nil
+	}
+	t.Cleanup(func() { attachRunForTesting = old })
+
+	b := &Cloud{}
+	op := &backend.Operation{Workspace: "my-workspace"}
+
+	r, err := b.StartOrAttachRun(context.Background(), op, false, func() (*tfe.Run, error) {
+		return nil, errors.New("createRun should not be called when a checkpoint is resumable")
+	})
+	if err != nil {
+		t.Fatalf("StartOrAttachRun() error = %v", err)
+	}
+	if r == nil || r.ID != "run-123" {
+		t.Fatalf("StartOrAttachRun() = %+v, want the checkpointed run to be reattached", r)
+	}
+}
+
+func TestStartOrAttachRunForceNewRunIgnoresCheckpoint(t *testing.T) {
+	withTestCheckpointPath(t)
+
+	if err := writeRunCheckpoint("my-workspace", "run-123"); err != nil {
+		t.Fatalf("writeRunCheckpoint() error = %v", err)
+	}
+
+	b := &Cloud{}
+	op := &backend.Operation{Workspace: "my-workspace"}
+
+	var created bool
+	r, err := b.StartOrAttachRun(context.Background(), op, true, func() (*tfe.Run, error) {
+		created = true
+		return &tfe.Run{ID: "run-456"}, nil
+	})
+	if err != nil {
+		t.Fatalf("StartOrAttachRun() error = %v", err)
+	}
+	if !created {
+		t.Fatal("StartOrAttachRun() did not call createRun when forceNewRun is true")
+	}
+	if r == nil || r.ID != "run-456" {
+		t.Fatalf("StartOrAttachRun() = %+v, want the freshly created run", r)
+	}
+}